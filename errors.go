@@ -0,0 +1,34 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// APIError is the structured representation of an error response body.
+type APIError struct {
+	StatusCode   int    `json:"status_code"`
+	StatusDesc   string `json:"status_desc"`
+	ErrorMessage string `json:"error_message"`
+	Field        string `json:"field,omitempty"`
+}
+
+// writeJSONError writes status and msg as a JSON-encoded APIError, optionally
+// naming the request field that caused it.
+func writeJSONError(w http.ResponseWriter, status int, msg, field string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	apiErr := APIError{
+		StatusCode:   status,
+		StatusDesc:   http.StatusText(status),
+		ErrorMessage: msg,
+		Field:        field,
+	}
+	if err := json.NewEncoder(w).Encode(struct {
+		Error APIError `json:"error"`
+	}{Error: apiErr}); err != nil {
+		log.Printf("writeJSONError: encoding response: %v", err)
+	}
+}
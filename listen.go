@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// defaultSocketMode is used when -socket-mode / MOTZKLIST_SOCKET_MODE isn't set.
+const defaultSocketMode = "0660"
+
+// listenAddr resolves the configured listen address, preferring the -listen
+// flag value when set and falling back to MOTZKLIST_LISTEN, then a TCP
+// default. Accepts "tcp://:8080" or "unix:///var/run/motzklist.sock".
+func listenAddr(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if env := os.Getenv("MOTZKLIST_LISTEN"); env != "" {
+		return env
+	}
+	return "tcp://:8080"
+}
+
+// listen opens a net.Listener for addr (scheme "tcp" or "unix"). For a unix
+// socket, it removes any stale socket file left behind by a previous run and
+// chmods the new one to mode (parsed as octal, e.g. "0660").
+func listen(addr, mode string) (net.Listener, error) {
+	scheme, rest, ok := strings.Cut(addr, "://")
+	if !ok {
+		return nil, fmt.Errorf("listen: address %q missing scheme (expected tcp:// or unix://)", addr)
+	}
+
+	switch scheme {
+	case "tcp":
+		return net.Listen("tcp", rest)
+	case "unix":
+		return listenUnix(rest, mode)
+	default:
+		return nil, fmt.Errorf("listen: unsupported scheme %q", scheme)
+	}
+}
+
+func listenUnix(path, mode string) (net.Listener, error) {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("listen: removing stale socket %q: %w", path, err)
+	}
+
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("listen: listening on unix socket %q: %w", path, err)
+	}
+
+	if mode == "" {
+		mode = defaultSocketMode
+	}
+	perm, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil {
+		ln.Close()
+		return nil, fmt.Errorf("listen: parsing socket mode %q: %w", mode, err)
+	}
+	if err := os.Chmod(path, os.FileMode(perm)); err != nil {
+		ln.Close()
+		return nil, fmt.Errorf("listen: chmod socket %q: %w", path, err)
+	}
+
+	return ln, nil
+}
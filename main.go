@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+)
+
+// server holds the dependencies shared by the HTTP handlers.
+type server struct {
+	store Store
+}
+
+func (s *server) getSchoolsHandler(w http.ResponseWriter, r *http.Request) {
+	schools, err := s.store.GetSchools(r.Context())
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "failed to fetch schools", "")
+		return
+	}
+	writeJSON(w, schools)
+}
+
+func (s *server) getGradesHandler(w http.ResponseWriter, r *http.Request) {
+	schoolID := r.URL.Query().Get("school_id")
+	if schoolID == "" {
+		writeJSONError(w, http.StatusBadRequest, "missing required query param: school_id", "school_id")
+		return
+	}
+
+	grades, err := s.store.GetGradesBySchoolID(r.Context(), schoolID)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "failed to fetch grades", "")
+		return
+	}
+	if grades == nil {
+		writeJSONError(w, http.StatusNotFound, "no grades found for school_id", "school_id")
+		return
+	}
+	writeJSON(w, grades)
+}
+
+func (s *server) getClassesHandler(w http.ResponseWriter, r *http.Request) {
+	schoolID := r.URL.Query().Get("school_id")
+	gradeID := r.URL.Query().Get("grade_id")
+	if schoolID == "" {
+		writeJSONError(w, http.StatusBadRequest, "missing required query param: school_id", "school_id")
+		return
+	}
+	if gradeID == "" {
+		writeJSONError(w, http.StatusBadRequest, "missing required query param: grade_id", "grade_id")
+		return
+	}
+
+	classes, err := s.store.GetClassesByGradeID(r.Context(), schoolID, gradeID)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "failed to fetch classes", "")
+		return
+	}
+	if classes == nil {
+		writeJSONError(w, http.StatusNotFound, "no classes found for school_id/grade_id", "grade_id")
+		return
+	}
+	writeJSON(w, classes)
+}
+
+func (s *server) getEquipmentListsHandler(w http.ResponseWriter, r *http.Request) {
+	schoolID := r.URL.Query().Get("school_id")
+	gradeID := r.URL.Query().Get("grade_id")
+	classID := r.URL.Query().Get("class_id")
+	if schoolID == "" {
+		writeJSONError(w, http.StatusBadRequest, "missing required query param: school_id", "school_id")
+		return
+	}
+	if gradeID == "" {
+		writeJSONError(w, http.StatusBadRequest, "missing required query param: grade_id", "grade_id")
+		return
+	}
+	if classID == "" {
+		writeJSONError(w, http.StatusBadRequest, "missing required query param: class_id", "class_id")
+		return
+	}
+
+	equipment, err := s.store.GetEquipmentList(r.Context(), schoolID, gradeID, classID)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "failed to fetch equipment list", "")
+		return
+	}
+	writeJSON(w, equipment)
+}
+
+// adminPingHandler is a minimal health check behind authMiddleware, proving
+// the /api/admin group requires a valid bearer token.
+func adminPingHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]string{"status": "ok"})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("writeJSON: encoding response: %v", err)
+	}
+}
+
+func main() {
+	listenFlag := flag.String("listen", "", "address to listen on: tcp://host:port or unix:///path/to.sock (default $MOTZKLIST_LISTEN or tcp://:8080)")
+	socketModeFlag := flag.String("socket-mode", defaultSocketMode, "octal file mode applied to a unix socket")
+	flag.Parse()
+
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		log.Fatal("DATABASE_URL must be set")
+	}
+
+	ctx := context.Background()
+	store, err := NewPgStore(ctx, databaseURL)
+	if err != nil {
+		log.Fatalf("main: %v", err)
+	}
+	defer store.Close()
+
+	addr := listenAddr(*listenFlag)
+	ln, err := listen(addr, *socketModeFlag)
+	if err != nil {
+		log.Fatalf("main: %v", err)
+	}
+
+	handler := chain(NewServer(store), loggingMiddleware, gzipMiddleware)
+	srv := &http.Server{Handler: handler}
+
+	sigCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-sigCtx.Done()
+		log.Println("shutting down")
+		if err := srv.Shutdown(context.Background()); err != nil {
+			log.Printf("main: shutdown: %v", err)
+		}
+	}()
+
+	if scheme, path, ok := strings.Cut(addr, "://"); ok && scheme == "unix" {
+		defer os.Remove(path)
+	}
+
+	log.Printf("listening on %s", addr)
+	if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+}
@@ -1,202 +1,392 @@
-// main_test.go
-package main
-
-import (
-	"encoding/json"
-	"net/http"
-	"net/http/httptest"
-	"testing"
-)
-
-// ---- Helper to decode JSON ----
-
-func decodeJSON[T any](t *testing.T, body *httptest.ResponseRecorder, out *T) {
-	t.Helper()
-	if err := json.Unmarshal(body.Body.Bytes(), out); err != nil {
-		t.Fatalf("failed to decode JSON: %v\nbody=%s", err, body.Body.String())
-	}
-}
-
-// ---- Handler tests ----
-
-func TestGetSchoolsHandler_OK(t *testing.T) {
-	req := httptest.NewRequest(http.MethodGet, "/api/schools", nil)
-	rr := httptest.NewRecorder()
-
-	// wrap with CORS, like in main()
-	handler := enableCORS(getSchoolsHandler)
-	handler.ServeHTTP(rr, req)
-
-	if rr.Code != http.StatusOK {
-		t.Fatalf("expected status 200, got %d", rr.Code)
-	}
-
-	// Check CORS header
-	if got := rr.Header().Get("Access-Control-Allow-Origin"); got != "*" {
-		t.Fatalf("expected CORS header '*', got %q", got)
-	}
-
-	var schools []School
-	decodeJSON(t, rr, &schools)
-
-	if len(schools) == 0 {
-		t.Fatalf("expected at least one school, got 0")
-	}
-}
-
-func TestGetGradesHandler_MissingParam(t *testing.T) {
-	req := httptest.NewRequest(http.MethodGet, "/api/grades", nil)
-	rr := httptest.NewRecorder()
-
-	handler := enableCORS(getGradesHandler)
-	handler.ServeHTTP(rr, req)
-
-	if rr.Code != http.StatusBadRequest {
-		t.Fatalf("expected status 400 for missing school_id, got %d", rr.Code)
-	}
-}
-
-func TestGetGradesHandler_ValidSchool(t *testing.T) {
-	// "1" is valid according to MockSchools in mock_db.go
-	req := httptest.NewRequest(http.MethodGet, "/api/grades?school_id=1", nil)
-	rr := httptest.NewRecorder()
-
-	handler := enableCORS(getGradesHandler)
-	handler.ServeHTTP(rr, req)
-
-	if rr.Code != http.StatusOK {
-		t.Fatalf("expected status 200, got %d", rr.Code)
-	}
-
-	var grades []Grade
-	decodeJSON(t, rr, &grades)
-	if len(grades) == 0 {
-		t.Fatalf("expected at least one grade for school_id=1")
-	}
-}
-
-func TestGetGradesHandler_InvalidSchool(t *testing.T) {
-	// school_id=999 should return nil from GetGradesBySchoolID
-	req := httptest.NewRequest(http.MethodGet, "/api/grades?school_id=999", nil)
-	rr := httptest.NewRecorder()
-
-	handler := enableCORS(getGradesHandler)
-	handler.ServeHTTP(rr, req)
-
-	// current implementation will encode `nil` as JSON "null" with 200 OK.
-	// We at least check it doesn't crash and returns 200.
-	if rr.Code != http.StatusOK {
-		t.Fatalf("expected status 200 even for invalid school (mocked), got %d", rr.Code)
-	}
-}
-
-func TestGetClassesHandler_MissingParams(t *testing.T) {
-	req := httptest.NewRequest(http.MethodGet, "/api/classes?school_id=1", nil) // missing grade_id
-	rr := httptest.NewRecorder()
-
-	handler := enableCORS(getClassesHandler)
-	handler.ServeHTTP(rr, req)
-
-	if rr.Code != http.StatusBadRequest {
-		t.Fatalf("expected status 400 for missing params, got %d", rr.Code)
-	}
-}
-
-func TestGetClassesHandler_OK(t *testing.T) {
-	req := httptest.NewRequest(http.MethodGet, "/api/classes?school_id=1&grade_id=9", nil)
-	rr := httptest.NewRecorder()
-
-	handler := enableCORS(getClassesHandler)
-	handler.ServeHTTP(rr, req)
-
-	if rr.Code != http.StatusOK {
-		t.Fatalf("expected status 200, got %d", rr.Code)
-	}
-
-	var classes []Class
-	decodeJSON(t, rr, &classes)
-	if len(classes) == 0 {
-		t.Fatalf("expected at least one class")
-	}
-}
-
-func TestGetEquipmentListsHandler_MissingParams(t *testing.T) {
-	req := httptest.NewRequest(http.MethodGet, "/api/equipment?school_id=1&grade_id=9", nil) // missing class_id
-	rr := httptest.NewRecorder()
-
-	handler := enableCORS(getEquipmentListsHandler)
-	handler.ServeHTTP(rr, req)
-
-	if rr.Code != http.StatusBadRequest {
-		t.Fatalf("expected status 400 for missing params, got %d", rr.Code)
-	}
-}
-
-func TestGetEquipmentListsHandler_DefaultList(t *testing.T) {
-	// This combination is not explicitly listed in MockEquipmentLists, so we hit "default"
-	req := httptest.NewRequest(http.MethodGet, "/api/equipment?school_id=1&grade_id=9&class_id=2", nil)
-	rr := httptest.NewRecorder()
-
-	handler := enableCORS(getEquipmentListsHandler)
-	handler.ServeHTTP(rr, req)
-
-	if rr.Code != http.StatusOK {
-		t.Fatalf("expected status 200, got %d", rr.Code)
-	}
-
-	var equipment []Equipment
-	decodeJSON(t, rr, &equipment)
-	if len(equipment) == 0 {
-		t.Fatalf("expected at least one equipment item")
-	}
-}
-func TestGetSchools(t *testing.T) {
-	schools := GetSchools()
-	if len(schools) == 0 {
-		t.Fatalf("expected non-empty schools list")
-	}
-}
-
-func TestGetGradesBySchoolID_Valid(t *testing.T) {
-	grades := GetGradesBySchoolID("1") // "1" exists in MockSchools
-	if len(grades) == 0 {
-		t.Fatalf("expected grades for valid school ID")
-	}
-}
-
-func TestGetGradesBySchoolID_Invalid(t *testing.T) {
-	grades := GetGradesBySchoolID("999")
-	if grades != nil {
-		t.Fatalf("expected nil for invalid school ID, got %+v", grades)
-	}
-}
-
-func TestGetClassesByGradeID_Valid(t *testing.T) {
-	classes := GetClassesByGradeID("1", "9")
-	if len(classes) == 0 {
-		t.Fatalf("expected classes for valid school/grade")
-	}
-}
-
-func TestGetClassesByGradeID_InvalidSchool(t *testing.T) {
-	classes := GetClassesByGradeID("999", "9")
-	if classes != nil {
-		t.Fatalf("expected nil for invalid school ID")
-	}
-}
-
-func TestGetEquipmentList_SpecificKey(t *testing.T) {
-	// "1-9-1" is explicitly defined in MockEquipmentLists
-	list := GetEquipmentList("1", "9", "1")
-	if len(list) == 0 {
-		t.Fatalf("expected non-empty list for 1-9-1")
-	}
-}
-
-func TestGetEquipmentList_DefaultKey(t *testing.T) {
-	// some combination that is not explicitly defined â†’ should hit "default"
-	list := GetEquipmentList("123", "456", "789")
-	if len(list) == 0 {
-		t.Fatalf("expected non-empty default list")
-	}
-}
\ No newline at end of file
+// main_test.go
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// decodeAPIError decodes a {"error": {...}} response body into an APIError.
+func decodeAPIError(t *testing.T, body *httptest.ResponseRecorder) APIError {
+	t.Helper()
+	var wrapper struct {
+		Error APIError `json:"error"`
+	}
+	if err := json.Unmarshal(body.Body.Bytes(), &wrapper); err != nil {
+		t.Fatalf("failed to decode JSON error: %v\nbody=%s", err, body.Body.String())
+	}
+	return wrapper.Error
+}
+
+// ---- Helper to decode JSON ----
+
+func decodeJSON[T any](t *testing.T, body *httptest.ResponseRecorder, out *T) {
+	t.Helper()
+	if err := json.Unmarshal(body.Body.Bytes(), out); err != nil {
+		t.Fatalf("failed to decode JSON: %v\nbody=%s", err, body.Body.String())
+	}
+}
+
+func newTestHandler() http.Handler {
+	return NewServer(MemoryStore{})
+}
+
+// ---- Handler tests ----
+
+func TestGetSchoolsHandler_OK(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/schools", nil)
+	rr := httptest.NewRecorder()
+
+	newTestHandler().ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+
+	// Check CORS header
+	if got := rr.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Fatalf("expected CORS header '*', got %q", got)
+	}
+
+	var schools []School
+	decodeJSON(t, rr, &schools)
+
+	if len(schools) == 0 {
+		t.Fatalf("expected at least one school, got 0")
+	}
+}
+
+func TestGetGradesHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantErr    APIError
+	}{
+		{
+			name:       "missing school_id",
+			query:      "",
+			wantStatus: http.StatusBadRequest,
+			wantErr: APIError{
+				StatusCode:   http.StatusBadRequest,
+				StatusDesc:   http.StatusText(http.StatusBadRequest),
+				ErrorMessage: "missing required query param: school_id",
+				Field:        "school_id",
+			},
+		},
+		{name: "valid school", query: "?school_id=1", wantStatus: http.StatusOK},
+		{
+			name:       "invalid school",
+			query:      "?school_id=999",
+			wantStatus: http.StatusNotFound,
+			wantErr: APIError{
+				StatusCode:   http.StatusNotFound,
+				StatusDesc:   http.StatusText(http.StatusNotFound),
+				ErrorMessage: "no grades found for school_id",
+				Field:        "school_id",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/grades"+tt.query, nil)
+			rr := httptest.NewRecorder()
+
+			newTestHandler().ServeHTTP(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, rr.Code)
+			}
+			if rr.Code != http.StatusOK {
+				if got := decodeAPIError(t, rr); !reflect.DeepEqual(got, tt.wantErr) {
+					t.Fatalf("expected error %+v, got %+v", tt.wantErr, got)
+				}
+				return
+			}
+
+			var grades []Grade
+			decodeJSON(t, rr, &grades)
+			if len(grades) == 0 {
+				t.Fatalf("expected at least one grade")
+			}
+		})
+	}
+}
+
+func TestGetClassesHandler_MissingParams(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/classes?school_id=1", nil) // missing grade_id
+	rr := httptest.NewRecorder()
+
+	newTestHandler().ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for missing params, got %d", rr.Code)
+	}
+
+	want := APIError{
+		StatusCode:   http.StatusBadRequest,
+		StatusDesc:   http.StatusText(http.StatusBadRequest),
+		ErrorMessage: "missing required query param: grade_id",
+		Field:        "grade_id",
+	}
+	if got := decodeAPIError(t, rr); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected error %+v, got %+v", want, got)
+	}
+}
+
+func TestGetClassesHandler_OK(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/classes?school_id=1&grade_id=9", nil)
+	rr := httptest.NewRecorder()
+
+	newTestHandler().ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+
+	var classes []Class
+	decodeJSON(t, rr, &classes)
+	if len(classes) == 0 {
+		t.Fatalf("expected at least one class")
+	}
+}
+
+func TestGetEquipmentListsHandler_MissingParams(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/equipment?school_id=1&grade_id=9", nil) // missing class_id
+	rr := httptest.NewRecorder()
+
+	newTestHandler().ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for missing params, got %d", rr.Code)
+	}
+
+	want := APIError{
+		StatusCode:   http.StatusBadRequest,
+		StatusDesc:   http.StatusText(http.StatusBadRequest),
+		ErrorMessage: "missing required query param: class_id",
+		Field:        "class_id",
+	}
+	if got := decodeAPIError(t, rr); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected error %+v, got %+v", want, got)
+	}
+}
+
+func TestGetEquipmentListsHandler_DefaultList(t *testing.T) {
+	// This combination is not explicitly listed in MockEquipmentLists, so we hit "default"
+	req := httptest.NewRequest(http.MethodGet, "/api/equipment?school_id=1&grade_id=9&class_id=2", nil)
+	rr := httptest.NewRecorder()
+
+	newTestHandler().ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+
+	var equipment []Equipment
+	decodeJSON(t, rr, &equipment)
+	if len(equipment) == 0 {
+		t.Fatalf("expected at least one equipment item")
+	}
+}
+
+// ---- Routing tests ----
+
+func TestRouter_MethodNotAllowed(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/api/schools", nil)
+	rr := httptest.NewRecorder()
+
+	newTestHandler().ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected status 405, got %d", rr.Code)
+	}
+	if got := rr.Header().Get("Allow"); got != "GET, OPTIONS" && got != "OPTIONS, GET" {
+		t.Fatalf("expected Allow header listing GET, got %q", got)
+	}
+}
+
+func TestRouter_NotFound(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/nope", nil)
+	rr := httptest.NewRecorder()
+
+	newTestHandler().ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", rr.Code)
+	}
+}
+
+func TestRouter_CORSPreflight(t *testing.T) {
+	req := httptest.NewRequest(http.MethodOptions, "/api/schools", nil)
+	rr := httptest.NewRecorder()
+
+	newTestHandler().ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", rr.Code)
+	}
+	if got := rr.Header().Get("Access-Control-Allow-Methods"); got == "" {
+		t.Fatalf("expected Access-Control-Allow-Methods to be set")
+	}
+	if got := rr.Header().Get("Access-Control-Allow-Headers"); got == "" {
+		t.Fatalf("expected Access-Control-Allow-Headers to be set")
+	}
+}
+
+// ---- Middleware tests ----
+
+func TestGzipMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		wantWrapped    bool
+	}{
+		{name: "client supports gzip", acceptEncoding: "gzip", wantWrapped: true},
+		{name: "client does not support gzip", acceptEncoding: "", wantWrapped: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotWriter http.ResponseWriter
+			inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotWriter = w
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/schools", nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			rr := httptest.NewRecorder()
+
+			gzipMiddleware(inner).ServeHTTP(rr, req)
+
+			_, wrapped := gotWriter.(*gzipResponseWriter)
+			if wrapped != tt.wantWrapped {
+				t.Fatalf("expected wrapped=%v, got %v", tt.wantWrapped, wrapped)
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	handler := NewServer(MemoryStore{})
+
+	tests := []struct {
+		name       string
+		authHeader string
+		wantStatus int
+	}{
+		{name: "malformed header", authHeader: "blabla", wantStatus: http.StatusUnauthorized},
+		{name: "valid bearer token", authHeader: "Bearer good", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/admin/ping", nil)
+			req.Header.Set("Authorization", tt.authHeader)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, rr.Code)
+			}
+		})
+	}
+}
+
+// ---- MemoryStore tests ----
+
+func TestMemoryStore_GetSchools(t *testing.T) {
+	schools, err := (MemoryStore{}).GetSchools(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(schools) == 0 {
+		t.Fatalf("expected non-empty schools list")
+	}
+}
+
+func TestMemoryStore_GetGradesBySchoolID(t *testing.T) {
+	tests := []struct {
+		name      string
+		schoolID  string
+		wantEmpty bool
+	}{
+		{name: "valid school", schoolID: "1", wantEmpty: false},
+		{name: "invalid school", schoolID: "999", wantEmpty: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grades, err := (MemoryStore{}).GetGradesBySchoolID(context.Background(), tt.schoolID)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantEmpty && grades != nil {
+				t.Fatalf("expected nil for invalid school ID, got %+v", grades)
+			}
+			if !tt.wantEmpty && len(grades) == 0 {
+				t.Fatalf("expected grades for valid school ID")
+			}
+		})
+	}
+}
+
+func TestMemoryStore_GetClassesByGradeID(t *testing.T) {
+	tests := []struct {
+		name      string
+		schoolID  string
+		gradeID   string
+		wantEmpty bool
+	}{
+		{name: "valid school/grade", schoolID: "1", gradeID: "9", wantEmpty: false},
+		{name: "invalid school", schoolID: "999", gradeID: "9", wantEmpty: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			classes, err := (MemoryStore{}).GetClassesByGradeID(context.Background(), tt.schoolID, tt.gradeID)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantEmpty && classes != nil {
+				t.Fatalf("expected nil for invalid school ID")
+			}
+			if !tt.wantEmpty && len(classes) == 0 {
+				t.Fatalf("expected classes for valid school/grade")
+			}
+		})
+	}
+}
+
+func TestMemoryStore_GetEquipmentList(t *testing.T) {
+	tests := []struct {
+		name     string
+		schoolID string
+		gradeID  string
+		classID  string
+	}{
+		{name: "specific key", schoolID: "1", gradeID: "9", classID: "1"},
+		{name: "default key", schoolID: "123", gradeID: "456", classID: "789"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := (MemoryStore{}).GetEquipmentList(context.Background(), tt.schoolID, tt.gradeID, tt.classID)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(list) == 0 {
+				t.Fatalf("expected non-empty list")
+			}
+		})
+	}
+}
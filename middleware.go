@@ -0,0 +1,111 @@
+package main
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Middleware wraps an http.Handler with additional behavior.
+type Middleware func(http.Handler) http.Handler
+
+// chain applies mws around h, with the first middleware in the list as the
+// outermost layer.
+func chain(h http.Handler, mws ...Middleware) http.Handler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
+
+// gzipResponseWriter wraps an http.ResponseWriter, sending writes through a
+// gzip.Writer instead of directly to the client.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz *gzip.Writer
+}
+
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	return w.gz.Write(b)
+}
+
+// gzipMiddleware compresses the response body when the client advertises
+// support for it via Accept-Encoding.
+func gzipMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		defer gz.Close()
+
+		next.ServeHTTP(&gzipResponseWriter{ResponseWriter: w, gz: gz}, r)
+	})
+}
+
+// statusRecorder captures the status code written by the handler it wraps.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// loggingMiddleware emits one JSON line per request describing the method,
+// path, status, duration, and remote address.
+func loggingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r)
+
+		entry, err := json.Marshal(struct {
+			Method     string `json:"method"`
+			Path       string `json:"path"`
+			Status     int    `json:"status"`
+			DurationMs int64  `json:"duration_ms"`
+			RemoteAddr string `json:"remote_addr"`
+		}{
+			Method:     r.Method,
+			Path:       r.URL.Path,
+			Status:     rec.status,
+			DurationMs: time.Since(start).Milliseconds(),
+			RemoteAddr: r.RemoteAddr,
+		})
+		if err != nil {
+			log.Printf("loggingMiddleware: encoding access log entry: %v", err)
+			return
+		}
+		log.Println(string(entry))
+	})
+}
+
+// bearerTokenContextKey is the context key under which authMiddleware stashes
+// the bearer token it validated.
+type bearerTokenContextKey struct{}
+
+// authMiddleware requires a "Bearer <token>" Authorization header, accepting
+// the scheme case-insensitively, and rejects requests without a valid one.
+func authMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+		if !ok || !strings.EqualFold(scheme, "bearer") || token == "" {
+			writeJSONError(w, http.StatusUnauthorized, "missing or invalid bearer token", "Authorization")
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), bearerTokenContextKey{}, token)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
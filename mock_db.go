@@ -0,0 +1,60 @@
+package main
+
+import "context"
+
+// MockSchools is the seed data used by MemoryStore.
+var MockSchools = map[string]School{
+	"1": {ID: "1", Name: "Lincoln Elementary"},
+	"2": {ID: "2", Name: "Washington Middle School"},
+}
+
+// MockGrades maps a school ID to the grades offered there.
+var MockGrades = map[string][]Grade{
+	"1": {{ID: "9", Name: "9th Grade"}, {ID: "10", Name: "10th Grade"}},
+	"2": {{ID: "6", Name: "6th Grade"}},
+}
+
+// MockClasses maps "schoolID-gradeID" to the classes within that grade.
+var MockClasses = map[string][]Class{
+	"1-9": {{ID: "1", Name: "Biology"}, {ID: "2", Name: "Algebra"}},
+	"2-6": {{ID: "1", Name: "General Science"}},
+}
+
+// MockEquipmentLists maps "schoolID-gradeID-classID" to its equipment list.
+// The "default" key is used as a fallback when no specific key matches.
+var MockEquipmentLists = map[string][]Equipment{
+	"1-9-1": {{ID: "1", Name: "Microscope"}, {ID: "2", Name: "Lab Goggles"}},
+	"default": {
+		{ID: "1", Name: "Notebook"},
+		{ID: "2", Name: "Pencil"},
+	},
+}
+
+// MemoryStore is an in-memory Store implementation backed by the Mock*
+// package variables above. It's used by tests and can stand in for pgStore
+// in environments without a Postgres connection.
+type MemoryStore struct{}
+
+func (MemoryStore) GetSchools(ctx context.Context) ([]School, error) {
+	schools := make([]School, 0, len(MockSchools))
+	for _, s := range MockSchools {
+		schools = append(schools, s)
+	}
+	return schools, nil
+}
+
+func (MemoryStore) GetGradesBySchoolID(ctx context.Context, schoolID string) ([]Grade, error) {
+	return MockGrades[schoolID], nil
+}
+
+func (MemoryStore) GetClassesByGradeID(ctx context.Context, schoolID, gradeID string) ([]Class, error) {
+	return MockClasses[schoolID+"-"+gradeID], nil
+}
+
+func (MemoryStore) GetEquipmentList(ctx context.Context, schoolID, gradeID, classID string) ([]Equipment, error) {
+	key := schoolID + "-" + gradeID + "-" + classID
+	if list, ok := MockEquipmentLists[key]; ok {
+		return list, nil
+	}
+	return MockEquipmentLists["default"], nil
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// pgStore is the Postgres-backed Store implementation used in production.
+type pgStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewPgStore opens a pgx connection pool against databaseURL and returns a
+// Store backed by it. Callers are responsible for closing the pool (via
+// pgStore.Close) on shutdown.
+func NewPgStore(ctx context.Context, databaseURL string) (*pgStore, error) {
+	pool, err := pgxpool.New(ctx, databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("pg_store: connecting to postgres: %w", err)
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("pg_store: pinging postgres: %w", err)
+	}
+	return &pgStore{pool: pool}, nil
+}
+
+// Close releases the underlying connection pool.
+func (s *pgStore) Close() {
+	s.pool.Close()
+}
+
+func (s *pgStore) GetSchools(ctx context.Context) ([]School, error) {
+	rows, err := s.pool.Query(ctx, `SELECT id, name FROM schools ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("pg_store: querying schools: %w", err)
+	}
+	defer rows.Close()
+
+	var schools []School
+	for rows.Next() {
+		var sch School
+		if err := rows.Scan(&sch.ID, &sch.Name); err != nil {
+			return nil, fmt.Errorf("pg_store: scanning school: %w", err)
+		}
+		schools = append(schools, sch)
+	}
+	return schools, rows.Err()
+}
+
+func (s *pgStore) GetGradesBySchoolID(ctx context.Context, schoolID string) ([]Grade, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT id, name FROM grades WHERE school_id = $1 ORDER BY id`, schoolID)
+	if err != nil {
+		return nil, fmt.Errorf("pg_store: querying grades: %w", err)
+	}
+	defer rows.Close()
+
+	var grades []Grade
+	for rows.Next() {
+		var g Grade
+		if err := rows.Scan(&g.ID, &g.Name); err != nil {
+			return nil, fmt.Errorf("pg_store: scanning grade: %w", err)
+		}
+		grades = append(grades, g)
+	}
+	return grades, rows.Err()
+}
+
+func (s *pgStore) GetClassesByGradeID(ctx context.Context, schoolID, gradeID string) ([]Class, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT id, name FROM classes WHERE school_id = $1 AND grade_id = $2 ORDER BY id`,
+		schoolID, gradeID)
+	if err != nil {
+		return nil, fmt.Errorf("pg_store: querying classes: %w", err)
+	}
+	defer rows.Close()
+
+	var classes []Class
+	for rows.Next() {
+		var c Class
+		if err := rows.Scan(&c.ID, &c.Name); err != nil {
+			return nil, fmt.Errorf("pg_store: scanning class: %w", err)
+		}
+		classes = append(classes, c)
+	}
+	return classes, rows.Err()
+}
+
+// GetEquipmentList looks up the equipment list for the exact school/grade/class
+// key. If no row matches that composite key, it falls back to the
+// school/grade/class-agnostic "default" list, mirroring the mock store's
+// fallback semantics.
+func (s *pgStore) GetEquipmentList(ctx context.Context, schoolID, gradeID, classID string) ([]Equipment, error) {
+	items, err := s.queryEquipmentList(ctx, schoolID, gradeID, classID)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) > 0 {
+		return items, nil
+	}
+	return s.queryEquipmentList(ctx, "default", "default", "default")
+}
+
+func (s *pgStore) queryEquipmentList(ctx context.Context, schoolID, gradeID, classID string) ([]Equipment, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT ei.id, ei.name
+		FROM equipment_items ei
+		JOIN equipment_lists el ON el.id = ei.equipment_list_id
+		WHERE el.school_id = $1 AND el.grade_id = $2 AND el.class_id = $3
+		ORDER BY ei.id`, schoolID, gradeID, classID)
+	if err != nil {
+		return nil, fmt.Errorf("pg_store: querying equipment list: %w", err)
+	}
+	defer rows.Close()
+
+	var items []Equipment
+	for rows.Next() {
+		var e Equipment
+		if err := rows.Scan(&e.ID, &e.Name); err != nil {
+			return nil, fmt.Errorf("pg_store: scanning equipment item: %w", err)
+		}
+		items = append(items, e)
+	}
+	return items, rows.Err()
+}
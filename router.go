@@ -0,0 +1,81 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// NewServer wires the handlers to store and returns the top-level HTTP
+// handler for the API.
+func NewServer(store Store) http.Handler {
+	s := &server{store: store}
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/schools", s.getSchoolsHandler).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc("/api/grades", s.getGradesHandler).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc("/api/classes", s.getClassesHandler).Methods(http.MethodGet, http.MethodOptions)
+	router.HandleFunc("/api/equipment", s.getEquipmentListsHandler).Methods(http.MethodGet, http.MethodOptions)
+
+	adminRouter := router.PathPrefix("/api/admin").Subrouter()
+	adminRouter.Use(authMiddleware)
+	adminRouter.HandleFunc("/ping", adminPingHandler).Methods(http.MethodGet, http.MethodOptions)
+
+	router.NotFoundHandler = http.HandlerFunc(notFoundHandler)
+	router.MethodNotAllowedHandler = methodNotAllowedHandler(router)
+
+	router.Use(corsMiddleware)
+
+	return router
+}
+
+// corsMiddleware allows cross-origin requests from any origin and short-
+// circuits CORS preflight (OPTIONS) requests with a 204, deriving the
+// allowed methods/headers from the route that matched the request path.
+func corsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+
+		if r.Method == http.MethodOptions {
+			if route := mux.CurrentRoute(r); route != nil {
+				if methods, err := route.GetMethods(); err == nil {
+					w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+				}
+			}
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// notFoundHandler is used when no registered route matches the request path.
+func notFoundHandler(w http.ResponseWriter, r *http.Request) {
+	http.Error(w, "404 page not found", http.StatusNotFound)
+}
+
+// methodNotAllowedHandler returns a handler that, when a route's path matches
+// but its method doesn't, reports 405 with an Allow header listing every
+// method that would have matched the same path.
+func methodNotAllowedHandler(router *mux.Router) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var allowed []string
+		_ = router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+			match := new(mux.RouteMatch)
+			if route.Match(r, match) || match.MatchErr == mux.ErrMethodMismatch {
+				if methods, err := route.GetMethods(); err == nil {
+					allowed = append(allowed, methods...)
+				}
+			}
+			return nil
+		})
+
+		if len(allowed) > 0 {
+			w.Header().Set("Allow", strings.Join(allowed, ", "))
+		}
+		http.Error(w, "405 method not allowed", http.StatusMethodNotAllowed)
+	})
+}
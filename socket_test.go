@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestServeUnixSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix domain sockets are not supported on windows")
+	}
+
+	sockPath := filepath.Join(t.TempDir(), "motzklist.sock")
+
+	ln, err := listen("unix://"+sockPath, "0660")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+
+	srv := &http.Server{Handler: NewServer(MemoryStore{})}
+	go srv.Serve(ln)
+	defer srv.Close()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return net.Dial("unix", sockPath)
+			},
+		},
+	}
+
+	resp, err := client.Get("http://unix/api/schools")
+	if err != nil {
+		t.Fatalf("GET /api/schools over unix socket: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	var schools []School
+	if err := json.NewDecoder(resp.Body).Decode(&schools); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if len(schools) == 0 {
+		t.Fatalf("expected at least one school, got 0")
+	}
+}
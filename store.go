@@ -0,0 +1,12 @@
+package main
+
+import "context"
+
+// Store is the data-access boundary used by the HTTP handlers. Production
+// wires up pgStore (backed by Postgres); tests use MemoryStore instead.
+type Store interface {
+	GetSchools(ctx context.Context) ([]School, error)
+	GetGradesBySchoolID(ctx context.Context, schoolID string) ([]Grade, error)
+	GetClassesByGradeID(ctx context.Context, schoolID, gradeID string) ([]Class, error)
+	GetEquipmentList(ctx context.Context, schoolID, gradeID, classID string) ([]Equipment, error)
+}
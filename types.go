@@ -0,0 +1,25 @@
+package main
+
+// School represents a school that can be looked up by ID.
+type School struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Grade represents a grade level offered at a school.
+type Grade struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Class represents a class within a grade.
+type Class struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Equipment represents a single item on an equipment list.
+type Equipment struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}